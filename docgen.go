@@ -0,0 +1,156 @@
+// Package docgen generates API documentation (RAML, OpenAPI, Postman,
+// Markdown) from a chi.Router, either as a one-shot build step or, via
+// Handler, as a live endpoint bound to a running router.
+//
+// Handler's "/" page is a plain route list linking to the generated specs;
+// no Swagger UI or ReDoc bundle is embedded.
+package docgen
+
+import (
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"sync"
+
+	"github.com/pressly/chi"
+	"github.com/pressly/chi/docgen/openapi"
+	"github.com/pressly/chi/docgen/raml"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Options configures Handler.
+type Options struct {
+	Title   string
+	Version string
+
+	// Debug, when true, regenerates the spec on every request instead of
+	// building it once and caching it - handy while routes are still
+	// changing. Leave false in production.
+	Debug bool
+
+	// OpenAPIFormatFn and RAMLFormatFn default to openapi.DeveloperDocs and
+	// raml.DeveloperDocs respectively.
+	OpenAPIFormatFn openapi.FormatFn
+	RAMLFormatFn    raml.FormatFn
+}
+
+// Handler serves live documentation for r: the generated specs at
+// "/openapi.json" and "/raml.yaml", the live route table as JSON at
+// "/routes", and a minimal built-in HTML viewer at "/" that links to both.
+// Mount it alongside the router it documents, e.g.
+// r.Mount("/docs", docgen.Handler(r, opts)).
+//
+// The page at "/" is ui/index.html, served from an embed.FS: it lists the
+// routes from "/routes" and links to the two specs. It is not Swagger UI
+// or ReDoc, and offers no inline "try it out" requests against the spec.
+func Handler(r chi.Routes, opts Options) http.Handler {
+	if opts.OpenAPIFormatFn == nil {
+		opts.OpenAPIFormatFn = openapi.DeveloperDocs
+	}
+	if opts.RAMLFormatFn == nil {
+		opts.RAMLFormatFn = raml.DeveloperDocs
+	}
+
+	h := &handler{r: r, opts: opts}
+
+	ui, err := fs.Sub(uiFS, "ui")
+	if err != nil {
+		panic(err) // ui/ is embedded at build time; this can't fail at runtime
+	}
+
+	mux := chi.NewRouter()
+	mux.Get("/openapi.json", h.serveOpenAPI)
+	mux.Get("/raml.yaml", h.serveRAML)
+	mux.Get("/routes", h.serveRoutes)
+	mux.Mount("/", http.FileServer(http.FS(ui)))
+
+	return mux
+}
+
+type handler struct {
+	r    chi.Routes
+	opts Options
+
+	mu          sync.Mutex
+	openapiSpec []byte
+	ramlSpec    []byte
+}
+
+func (h *handler) serveOpenAPI(w http.ResponseWriter, r *http.Request) {
+	body, err := h.cached(&h.openapiSpec, h.buildOpenAPI)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+func (h *handler) serveRAML(w http.ResponseWriter, r *http.Request) {
+	body, err := h.cached(&h.ramlSpec, h.buildRAML)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Write(body)
+}
+
+// cached returns *dst, building it with build the first time (or every
+// time, in Debug mode).
+func (h *handler) cached(dst *[]byte, build func() ([]byte, error)) ([]byte, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.opts.Debug && *dst != nil {
+		return *dst, nil
+	}
+
+	body, err := build()
+	if err != nil {
+		return nil, err
+	}
+	*dst = body
+	return body, nil
+}
+
+func (h *handler) buildOpenAPI() ([]byte, error) {
+	doc := openapi.New(h.opts.Title, h.opts.Version)
+	if err := doc.AddResourcesFmt(h.r, h.opts.OpenAPIFormatFn); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func (h *handler) buildRAML() ([]byte, error) {
+	doc := &raml.RAML{Title: h.opts.Title, Version: h.opts.Version}
+	if err := doc.AddResourcesFmt(h.r, h.opts.RAMLFormatFn); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(doc)
+}
+
+// routeEntry is one row of the JSON array served at "/routes".
+type routeEntry struct {
+	Method string `json:"method"`
+	Route  string `json:"route"`
+}
+
+func (h *handler) serveRoutes(w http.ResponseWriter, r *http.Request) {
+	var routes []routeEntry
+
+	err := chi.Walk(h.r, func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		if method == "*" {
+			return nil
+		}
+		routes = append(routes, routeEntry{Method: method, Route: route})
+		return nil
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(routes)
+}
@@ -0,0 +1,52 @@
+package docgen_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pressly/chi"
+	"github.com/pressly/chi/docgen"
+)
+
+func TestHandler(t *testing.T) {
+	r := chi.NewRouter()
+	r.Get("/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("pong"))
+	})
+	r.Mount("/docs", docgen.Handler(r, docgen.Options{Title: "Test API", Version: "v1"}))
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/docs/routes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	var routes []struct {
+		Method string `json:"method"`
+		Route  string `json:"route"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&routes); err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, route := range routes {
+		if route.Method == "GET" && route.Route == "/ping" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected /routes to include GET /ping")
+	}
+
+	if res, err := http.Get(srv.URL + "/docs/openapi.json"); err != nil {
+		t.Fatal(err)
+	} else if res.StatusCode != http.StatusOK {
+		t.Errorf("GET /docs/openapi.json: got status %d", res.StatusCode)
+	}
+}
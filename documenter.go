@@ -0,0 +1,19 @@
+package docgen
+
+import "github.com/pressly/chi/docgen/raml"
+
+// Documenter, DocumenterFunc and WrapMiddleware live in package raml -
+// DocumentRoute needs *raml.Resource, and raml can't import this package
+// back without a cycle. They're aliased here since most callers reach them
+// through docgen's root package.
+//
+// This is a RAML-only feature: DocumentRoute's contributions (query
+// params, headers, response codes) are written directly onto a
+// *raml.Resource, and neither openapi.Operation nor postman.Item has an
+// equivalent shape to translate them into. A middleware wrapped via
+// WrapMiddleware only documents itself in raml.DeveloperDocs output;
+// openapi.DeveloperDocs and postman.DeveloperDocs ignore it.
+type Documenter = raml.Documenter
+type DocumenterFunc = raml.DocumenterFunc
+
+var WrapMiddleware = raml.WrapMiddleware
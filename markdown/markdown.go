@@ -0,0 +1,275 @@
+// Package markdown generates a Markdown API reference from a chi.Router,
+// either as a single document or as one file per top-level resource.
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pressly/chi"
+	"github.com/pressly/chi/docgen/schema"
+)
+
+// Options configures Generate and GenerateFiles.
+type Options struct {
+	Title string
+
+	// BaseURL prefixes the curl example for every route. Defaults to
+	// "http://localhost:3000".
+	BaseURL string
+
+	// Resolver supplies schemas for types the AST walker in docgen/schema
+	// can't resolve on its own, and may be nil.
+	Resolver schema.Resolver
+}
+
+func (o Options) baseURL() string {
+	if o.BaseURL != "" {
+		return o.BaseURL
+	}
+	return "http://localhost:3000"
+}
+
+type route struct {
+	method   string
+	path     string
+	comment  string
+	body     *schema.Schema
+	response *schema.Schema
+}
+
+// group is a set of routes sharing a top-level path segment, e.g. all
+// "/articles/..." routes.
+type group struct {
+	name   string
+	routes []route
+}
+
+// Generate walks r and renders a single Markdown document: a table of
+// contents grouping routes by their top-level path segment, followed by
+// one "## METHOD /path" section per route with the handler's doc comment,
+// a runnable curl example, and fenced JSON Schema blocks for any request
+// or response body docgen/schema could infer.
+func Generate(r chi.Routes, opts Options) (string, error) {
+	groups, err := walkGroups(r, opts)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if opts.Title != "" {
+		fmt.Fprintf(&buf, "# %s\n\n", opts.Title)
+	}
+	writeTOC(&buf, groups)
+	for _, g := range groups {
+		for _, rt := range g.routes {
+			writeRoute(&buf, rt, opts)
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// GenerateFiles is Generate's per-resource counterpart: it returns one
+// Markdown document per top-level path segment (keyed by "<segment>.md",
+// or "index.md" for routes mounted directly at "/"), each with its own
+// title and table of contents.
+func GenerateFiles(r chi.Routes, opts Options) (map[string]string, error) {
+	groups, err := walkGroups(r, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string, len(groups))
+	for _, g := range groups {
+		name := strings.TrimPrefix(g.name, "/")
+		if name == "" {
+			name = "index"
+		}
+
+		var buf bytes.Buffer
+		title := opts.Title
+		if g.name != "" {
+			title = strings.Title(strings.TrimPrefix(g.name, "/"))
+		}
+		if title != "" {
+			fmt.Fprintf(&buf, "# %s\n\n", title)
+		}
+		writeTOC(&buf, []group{g})
+		for _, rt := range g.routes {
+			writeRoute(&buf, rt, opts)
+		}
+
+		files[name+".md"] = buf.String()
+	}
+
+	return files, nil
+}
+
+func walkGroups(r chi.Routes, opts Options) ([]group, error) {
+	byPrefix := map[string]*group{}
+	var order []string
+
+	err := chi.Walk(r, func(method string, path string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		if method == "*" {
+			return nil
+		}
+
+		info := chi.GetFuncInfo(handler)
+		body, response, _ := schema.Infer(info.File, info.Func, opts.Resolver)
+
+		prefix := topLevelSegment(path)
+		g, ok := byPrefix[prefix]
+		if !ok {
+			g = &group{name: prefix}
+			byPrefix[prefix] = g
+			order = append(order, prefix)
+		}
+		g.routes = append(g.routes, route{
+			method:   method,
+			path:     path,
+			comment:  info.Comment,
+			body:     body,
+			response: response,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]group, 0, len(order))
+	for _, prefix := range order {
+		groups = append(groups, *byPrefix[prefix])
+	}
+	return groups, nil
+}
+
+// topLevelSegment returns route's first path segment, e.g. "/articles" for
+// "/articles/:articleID", or "" for routes mounted directly at "/".
+func topLevelSegment(route string) string {
+	trimmed := strings.TrimPrefix(route, "/")
+	if trimmed == "" {
+		return ""
+	}
+	if i := strings.Index(trimmed, "/"); i >= 0 {
+		trimmed = trimmed[:i]
+	}
+	return "/" + trimmed
+}
+
+func writeTOC(buf *bytes.Buffer, groups []group) {
+	fmt.Fprintf(buf, "## Table of Contents\n\n")
+	for _, g := range groups {
+		if g.name != "" {
+			fmt.Fprintf(buf, "- **%s**\n", g.name)
+			for _, rt := range g.routes {
+				fmt.Fprintf(buf, "  - [%s %s](#%s)\n", rt.method, rt.path, anchor(rt))
+			}
+			continue
+		}
+		for _, rt := range g.routes {
+			fmt.Fprintf(buf, "- [%s %s](#%s)\n", rt.method, rt.path, anchor(rt))
+		}
+	}
+	fmt.Fprintf(buf, "\n")
+}
+
+var anchorNonWord = regexp.MustCompile(`[^a-z0-9]+`)
+
+// anchor approximates GitHub's heading-to-fragment slug, e.g.
+// "GET /articles/:articleID" -> "get-articles-articleid".
+func anchor(rt route) string {
+	s := strings.ToLower(rt.method + " " + rt.path)
+	s = anchorNonWord.ReplaceAllString(s, "-")
+	return strings.Trim(s, "-")
+}
+
+func writeRoute(buf *bytes.Buffer, rt route, opts Options) {
+	fmt.Fprintf(buf, "## %s %s\n\n", rt.method, rt.path)
+
+	if rt.comment != "" {
+		fmt.Fprintf(buf, "%s\n\n", rt.comment)
+	}
+
+	fmt.Fprintf(buf, "```bash\n%s\n```\n\n", curlExample(rt, opts))
+
+	if rt.body != nil {
+		fmt.Fprintf(buf, "**Request body**\n\n```json\n%s\n```\n\n", schemaJSON(rt.body))
+	}
+	if rt.response != nil {
+		fmt.Fprintf(buf, "**Response**\n\n```json\n%s\n```\n\n", schemaJSON(rt.response))
+	}
+}
+
+var curlParamRe = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+// curlExample renders a runnable curl command for rt, turning chi's
+// `:param` syntax into `{param}`-style placeholder tokens.
+func curlExample(rt route, opts Options) string {
+	path := curlParamRe.ReplaceAllString(rt.path, "{$1}")
+	url := strings.TrimRight(opts.baseURL(), "/") + path
+
+	if rt.method == "GET" {
+		return fmt.Sprintf("curl %s", url)
+	}
+	if rt.body != nil {
+		return fmt.Sprintf("curl -X %s %s \\\n  -H \"Content-Type: application/json\" \\\n  -d '%s'", rt.method, url, schemaJSON(rt.body))
+	}
+	return fmt.Sprintf("curl -X %s %s", rt.method, url)
+}
+
+func schemaJSON(s *schema.Schema) string {
+	// schema.Schema marshals to JSON Schema; render its properties as a
+	// minimal example payload instead, which is more useful inline in a
+	// curl command and a fenced body block than the schema itself.
+	return exampleJSON(s, "  ")
+}
+
+func exampleJSON(s *schema.Schema, indent string) string {
+	if s == nil {
+		return "{}"
+	}
+
+	switch s.Type {
+	case "object":
+		keys := make([]string, 0, len(s.Properties))
+		for k := range s.Properties {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		if len(keys) == 0 {
+			return "{}"
+		}
+
+		var b strings.Builder
+		b.WriteString("{\n")
+		for i, k := range keys {
+			b.WriteString(indent)
+			fmt.Fprintf(&b, "%q: %s", k, exampleJSON(s.Properties[k], indent+"  "))
+			if i < len(keys)-1 {
+				b.WriteString(",")
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(strings.TrimSuffix(indent, "  "))
+		b.WriteString("}")
+		return b.String()
+	case "array":
+		return "[" + exampleJSON(s.Items, indent) + "]"
+	case "string":
+		return `"string"`
+	case "number":
+		return "0"
+	case "boolean":
+		return "false"
+	default:
+		return "null"
+	}
+}
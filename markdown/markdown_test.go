@@ -0,0 +1,92 @@
+package markdown_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pressly/chi"
+	"github.com/pressly/chi/docgen/markdown"
+	"github.com/pressly/chi/render"
+)
+
+func TestGenerate(t *testing.T) {
+	r := Router()
+
+	doc, err := markdown.Generate(r, markdown.Options{Title: "Big Mux"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(doc, "## GET /articles/:articleID") {
+		t.Error("expected an H2 section for GET /articles/:articleID")
+	}
+	if !strings.Contains(doc, "curl http://localhost:3000/articles/{articleID}") {
+		t.Error("expected a curl example with :articleID rewritten to {articleID}")
+	}
+	if !strings.Contains(doc, `"id": "string"`) {
+		t.Error("expected the inferred response schema to surface as an example JSON body")
+	}
+}
+
+func TestGenerateFiles(t *testing.T) {
+	r := Router()
+
+	files, err := markdown.GenerateFiles(r, markdown.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := files["articles.md"]; !ok {
+		t.Error(`expected a separate "articles.md" file`)
+	}
+	if _, ok := files["admin.md"]; !ok {
+		t.Error(`expected a separate "admin.md" file`)
+	}
+}
+
+// Copy-pasted from _examples/raml. We can't simply import it, since it's main pkg.
+func Router() chi.Router {
+	r := chi.NewRouter()
+
+	r.Route("/articles", func(r chi.Router) {
+		r.Post("/", CreateArticle)
+
+		r.Route("/:articleID", func(r chi.Router) {
+			r.Get("/", GetArticle)
+		})
+	})
+
+	r.Mount("/admin", adminRouter())
+
+	return r
+}
+
+type Article struct {
+	ID string `json:"id"`
+}
+
+// CreateArticle persists the posted Article.
+func CreateArticle(w http.ResponseWriter, r *http.Request) {
+	var data struct {
+		*Article
+		OmitID interface{} `json:"id,omitempty"`
+	}
+	render.Bind(r.Body, &data)
+	render.JSON(w, r, data.Article)
+}
+
+// GetArticle returns the specific Article, pulled off the request context
+// the way raml/raml_test.go's GetArticle does.
+func GetArticle(w http.ResponseWriter, r *http.Request) {
+	article := r.Context().Value("article").(*Article)
+	render.JSON(w, r, article)
+}
+
+func adminRouter() chi.Router {
+	r := chi.NewRouter()
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("admin"))
+	})
+	return r
+}
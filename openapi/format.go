@@ -0,0 +1,98 @@
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/pressly/chi"
+	"github.com/pressly/chi/docgen/schema"
+)
+
+type FormatFn func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) (*Operation, error)
+
+// AddResourcesFmt walks r, building an Operation for every route via fn and
+// adding it to doc. It mirrors raml.AddResourcesFmt so the two packages can
+// be swapped in for one another.
+func (doc *OpenAPI) AddResourcesFmt(r chi.Routes, fn FormatFn) error {
+	return chi.Walk(r, func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		op, err := fn(method, route, handler, middlewares...)
+		if err != nil {
+			return err
+		}
+		if op == nil {
+			return nil
+		}
+		return doc.Add(method, route, op)
+	})
+}
+
+// DeveloperDocs is the default FormatFn. It fills in operationId from the
+// handler's chi.FuncInfo and the same status-code defaults the RAML
+// formatter uses (201 for POST, 200 for GET/PUT, 204 for DELETE).
+//
+// Unlike raml.DeveloperDocs, it doesn't consult docgen.Documenter:
+// Documenter writes directly onto a *raml.Resource, which Operation has
+// no equivalent of, so a middleware wrapped via docgen.WrapMiddleware
+// only documents itself in RAML output.
+func DeveloperDocs(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) (*Operation, error) {
+	if method == "*" {
+		return nil, nil
+	}
+
+	info := chi.GetFuncInfo(handler)
+
+	op := &Operation{
+		OperationID: info.Func,
+		Description: info.Comment,
+		Responses:   Responses{},
+	}
+
+	switch method {
+	case "POST":
+		op.Responses[201] = Response{Description: http.StatusText(201)}
+	case "GET", "PUT":
+		op.Responses[200] = Response{Description: http.StatusText(200)}
+	case "DELETE":
+		op.Responses[204] = Response{Description: http.StatusText(204)}
+	}
+
+	return op, nil
+}
+
+// DeveloperDocsWithResolver returns a FormatFn that behaves like
+// DeveloperDocs, but additionally inspects the handler's source for the
+// struct passed to render.Bind and render.JSON and fills in the resulting
+// JSON Schema under Operation.RequestBody and each Response's content.
+// resolver supplies schemas for types the AST walker can't resolve on its
+// own and may be nil.
+func DeveloperDocsWithResolver(resolver schema.Resolver) FormatFn {
+	return func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) (*Operation, error) {
+		op, err := DeveloperDocs(method, route, handler, middlewares...)
+		if err != nil || op == nil {
+			return op, err
+		}
+
+		info := chi.GetFuncInfo(handler)
+		body, response, err := schema.Infer(info.File, info.Func, resolver)
+		if err != nil {
+			return op, nil
+		}
+
+		if body != nil {
+			op.RequestBody = &RequestBody{Content: mediaTypeOf(body)}
+		}
+		if response != nil {
+			for code, resp := range op.Responses {
+				resp.Content = mediaTypeOf(response)
+				op.Responses[code] = resp
+			}
+		}
+
+		return op, nil
+	}
+}
+
+func mediaTypeOf(s *schema.Schema) map[string]MediaType {
+	return map[string]MediaType{
+		"application/json": {Schema: s},
+	}
+}
@@ -0,0 +1,117 @@
+package openapi
+
+import (
+	"regexp"
+	"strings"
+)
+
+// OpenAPI is the root document produced by this package. It covers the
+// minimal slice of the OpenAPI 3.0 object model that docgen needs in order
+// to describe a chi.Router: info, servers, and a flat path map.
+type OpenAPI struct {
+	OpenAPI string               `json:"openapi" yaml:"openapi"`
+	Info    Info                 `json:"info" yaml:"info"`
+	Servers []Server             `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Paths   map[string]*Resource `json:"paths" yaml:"paths"`
+}
+
+type Info struct {
+	Title   string `json:"title" yaml:"title"`
+	Version string `json:"version" yaml:"version"`
+}
+
+type Server struct {
+	URL string `json:"url" yaml:"url"`
+}
+
+// Resource is a single path entry (OpenAPI's PathItem), keyed by HTTP
+// method to the Operation that serves it.
+type Resource struct {
+	Get    *Operation `json:"get,omitempty" yaml:"get,omitempty"`
+	Post   *Operation `json:"post,omitempty" yaml:"post,omitempty"`
+	Put    *Operation `json:"put,omitempty" yaml:"put,omitempty"`
+	Patch  *Operation `json:"patch,omitempty" yaml:"patch,omitempty"`
+	Delete *Operation `json:"delete,omitempty" yaml:"delete,omitempty"`
+}
+
+// Operation describes a single method on a Resource.
+type Operation struct {
+	OperationID string       `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Description string       `json:"description,omitempty" yaml:"description,omitempty"`
+	RequestBody *RequestBody `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   Responses    `json:"responses" yaml:"responses"`
+}
+
+type Responses map[int]Response
+
+type Response struct {
+	Description string               `json:"description" yaml:"description"`
+	Content     map[string]MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+type RequestBody struct {
+	Content map[string]MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// MediaType holds the JSON Schema for a single content type, e.g.
+// "application/json".
+type MediaType struct {
+	Schema interface{} `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// New returns an empty document with its Paths map initialized, ready to
+// be passed to AddResourcesFmt.
+func New(title, version string) *OpenAPI {
+	return &OpenAPI{
+		OpenAPI: "3.0.0",
+		Info:    Info{Title: title, Version: version},
+		Paths:   map[string]*Resource{},
+	}
+}
+
+// Add registers the operation under method/route, converting route from
+// chi's `:param` syntax to OpenAPI's `{param}` path templates and creating
+// the path entry the first time it's seen. Methods Resource has no field
+// for (HEAD, OPTIONS, CONNECT, TRACE) are skipped rather than rejected, so
+// one CORS-preflight route leaves that route undocumented instead of
+// failing the whole walk.
+func (doc *OpenAPI) Add(method string, route string, op *Operation) error {
+	path := chiRouteToOAPIPath(route)
+
+	resource, ok := doc.Paths[path]
+	if !ok {
+		resource = &Resource{}
+		doc.Paths[path] = resource
+	}
+
+	switch method {
+	case "GET":
+		resource.Get = op
+	case "POST":
+		resource.Post = op
+	case "PUT":
+		resource.Put = op
+	case "PATCH":
+		resource.Patch = op
+	case "DELETE":
+		resource.Delete = op
+	}
+
+	return nil
+}
+
+var chiParamRe = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+// chiRouteToOAPIPath converts chi's `:param` path syntax to OpenAPI's
+// `{param}` template syntax, e.g. "/articles/:articleID" becomes
+// "/articles/{articleID}". chi.Walk reports the index handler of a
+// sub-router with a trailing slash (e.g. "/articles/:articleID/"); that's
+// trimmed since OpenAPI treats "/foo" and "/foo/" as distinct paths and a
+// trailing slash here is a routing artifact, not part of the resource.
+func chiRouteToOAPIPath(route string) string {
+	path := chiParamRe.ReplaceAllString(route, "{$1}")
+	if len(path) > 1 && strings.HasSuffix(path, "/") {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return path
+}
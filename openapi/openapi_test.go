@@ -0,0 +1,210 @@
+package openapi_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"testing"
+
+	"encoding/json"
+
+	"github.com/pressly/chi"
+	"github.com/pressly/chi/docgen/openapi"
+	"github.com/pressly/chi/middleware"
+	"github.com/pressly/chi/render"
+)
+
+func TestWalkerOpenAPI(t *testing.T) {
+	r := Router()
+
+	doc := openapi.New("Big Mux", "v1.0")
+
+	if err := doc.AddResourcesFmt(r, openapi.DeveloperDocs); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := json.Marshal(doc); err != nil {
+		t.Error(err)
+	}
+
+	if _, ok := doc.Paths["/articles/{articleID}"]; !ok {
+		t.Error("expected /articles/{articleID} to be present in the generated paths")
+	}
+}
+
+// TestWalkerOpenAPIUnsupportedMethod ensures a route registered with a
+// method OpenAPI's Resource has no field for (e.g. a CORS preflight
+// OPTIONS route) doesn't abort AddResourcesFmt for the rest of the router.
+func TestWalkerOpenAPIUnsupportedMethod(t *testing.T) {
+	r := chi.NewRouter()
+	r.Get("/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("pong"))
+	})
+	r.Options("/cors", func(w http.ResponseWriter, r *http.Request) {})
+
+	doc := openapi.New("CORS Mux", "v1.0")
+	if err := doc.AddResourcesFmt(r, openapi.DeveloperDocs); err != nil {
+		t.Fatalf("AddResourcesFmt returned an error for an OPTIONS route: %v", err)
+	}
+
+	if _, ok := doc.Paths["/ping"]; !ok {
+		t.Error("expected /ping to still be documented")
+	}
+}
+
+// Copy-pasted from _examples/raml. We can't simply import it, since it's main pkg.
+func Router() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(middleware.RequestID)
+	r.Use(middleware.Logger)
+	r.Use(middleware.Recoverer)
+
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("root."))
+	})
+
+	r.Get("/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("pong"))
+	})
+
+	// RESTy routes for "articles" resource
+	r.Route("/articles", func(r chi.Router) {
+		r.With(paginate).Get("/", ListArticles)
+		r.Post("/", CreateArticle) // POST /articles
+
+		r.Route("/:articleID", func(r chi.Router) {
+			r.Use(ArticleCtx)            // Load the *Article on the request context
+			r.Get("/", GetArticle)       // GET /articles/123
+			r.Put("/", UpdateArticle)    // PUT /articles/123
+			r.Delete("/", DeleteArticle) // DELETE /articles/123
+		})
+	})
+
+	return r
+}
+
+type Article struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// Article fixture data
+var articles = []*Article{
+	{ID: "1", Title: "Hi"},
+	{ID: "2", Title: "sup"},
+}
+
+// ArticleCtx middleware is used to load an Article object from
+// the URL parameters passed through as the request.
+func ArticleCtx(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		articleID := chi.URLParam(r, "articleID")
+		article, err := dbGetArticle(articleID)
+		if err != nil {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, http.StatusText(http.StatusNotFound))
+			return
+		}
+		ctx := context.WithValue(r.Context(), "article", article)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ListArticles returns an array of Articles.
+func ListArticles(w http.ResponseWriter, r *http.Request) {
+	render.JSON(w, r, articles)
+}
+
+// CreateArticle persists the posted Article and returns it
+// back to the client as an acknowledgement.
+func CreateArticle(w http.ResponseWriter, r *http.Request) {
+	var data struct {
+		*Article
+		OmitID interface{} `json:"id,omitempty"`
+	}
+
+	if err := render.Bind(r.Body, &data); err != nil {
+		render.JSON(w, r, err.Error())
+		return
+	}
+
+	article := data.Article
+	dbNewArticle(article)
+
+	render.JSON(w, r, article)
+}
+
+// GetArticle returns the specific Article.
+func GetArticle(w http.ResponseWriter, r *http.Request) {
+	article := r.Context().Value("article").(*Article)
+	render.JSON(w, r, article)
+}
+
+// UpdateArticle updates an existing Article in our persistent store.
+func UpdateArticle(w http.ResponseWriter, r *http.Request) {
+	article := r.Context().Value("article").(*Article)
+
+	data := struct {
+		*Article
+		OmitID interface{} `json:"id,omitempty"`
+	}{Article: article}
+
+	if err := render.Bind(r.Body, &data); err != nil {
+		render.JSON(w, r, err)
+		return
+	}
+	article = data.Article
+
+	render.JSON(w, r, article)
+}
+
+// DeleteArticle removes an existing Article from our persistent store.
+func DeleteArticle(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	article := r.Context().Value("article").(*Article)
+
+	article, err = dbRemoveArticle(article.ID)
+	if err != nil {
+		render.JSON(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, article)
+}
+
+// paginate is a stub, but very possible to implement middleware logic
+// to handle the request params for handling a paginated request.
+func paginate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+	})
+}
+
+func dbNewArticle(article *Article) (string, error) {
+	article.ID = fmt.Sprintf("%d", rand.Intn(100)+10)
+	articles = append(articles, article)
+	return article.ID, nil
+}
+
+func dbGetArticle(id string) (*Article, error) {
+	for _, a := range articles {
+		if a.ID == id {
+			return a, nil
+		}
+	}
+	return nil, errors.New("article not found.")
+}
+
+func dbRemoveArticle(id string) (*Article, error) {
+	for i, a := range articles {
+		if a.ID == id {
+			articles = append((articles)[:i], (articles)[i+1:]...)
+			return a, nil
+		}
+	}
+	return nil, errors.New("article not found.")
+}
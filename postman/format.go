@@ -0,0 +1,95 @@
+package postman
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pressly/chi"
+)
+
+type FormatFn func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) (*Item, error)
+
+// AddResourcesFmt walks r, building an Item for every route via fn and
+// adding it to the collection, folder-grouped by route's mount point.
+func (c *Collection) AddResourcesFmt(r chi.Routes, fn FormatFn) error {
+	return chi.Walk(r, func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		item, err := fn(method, route, handler, middlewares...)
+		if err != nil {
+			return err
+		}
+		if item == nil {
+			return nil
+		}
+		c.Add(route, item)
+		return nil
+	})
+}
+
+// DeveloperDocs is the default FormatFn. It splits route into path
+// segments, turning chi's `:param` syntax into Postman path variables, and
+// attaches an example response using the same status-code defaults the
+// RAML formatter uses (201 for POST, 200 for GET/PUT, 204 for DELETE).
+//
+// Unlike raml.DeveloperDocs, it doesn't consult docgen.Documenter:
+// Documenter writes directly onto a *raml.Resource, which Item has no
+// equivalent of, so a middleware wrapped via docgen.WrapMiddleware only
+// documents itself in RAML output.
+func DeveloperDocs(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) (*Item, error) {
+	if method == "*" {
+		return nil, nil
+	}
+
+	info := chi.GetFuncInfo(handler)
+
+	segments := strings.Split(strings.Trim(route, "/"), "/")
+	path := make([]string, 0, len(segments))
+	var vars []Variable
+	for _, seg := range segments {
+		if seg == "" {
+			continue
+		}
+		if strings.HasPrefix(seg, ":") {
+			name := seg[1:]
+			path = append(path, ":"+name)
+			vars = append(vars, Variable{Key: name, Value: "<" + name + ">"})
+			continue
+		}
+		path = append(path, seg)
+	}
+
+	item := &Item{
+		Name: fmt.Sprintf("%s %s", method, route),
+		Request: &Request{
+			Method:      method,
+			Description: info.Comment,
+			URL: URL{
+				Raw:      "{{baseUrl}}/" + strings.Join(path, "/"),
+				Host:     []string{"{{baseUrl}}"},
+				Path:     path,
+				Variable: vars,
+			},
+		},
+	}
+
+	status := 0
+	switch method {
+	case "POST":
+		status = http.StatusCreated
+	case "GET", "PUT":
+		status = http.StatusOK
+	case "DELETE":
+		status = http.StatusNoContent
+	}
+	if status != 0 {
+		item.Response = []Response{
+			{
+				Name:   info.Comment,
+				Status: http.StatusText(status),
+				Code:   status,
+			},
+		}
+	}
+
+	return item, nil
+}
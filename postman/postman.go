@@ -0,0 +1,91 @@
+package postman
+
+import "strings"
+
+// Collection is a Postman Collection v2.1.0 document built from a walked
+// chi.Router.
+type Collection struct {
+	Info     Info       `json:"info"`
+	Item     []*Item    `json:"item"`
+	Variable []Variable `json:"variable,omitempty"`
+}
+
+type Info struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+}
+
+// Item is either a request (Request non-nil) or a folder grouping other
+// Items, matching Postman's recursive collection format.
+type Item struct {
+	Name     string     `json:"name"`
+	Item     []*Item    `json:"item,omitempty"`
+	Request  *Request   `json:"request,omitempty"`
+	Response []Response `json:"response,omitempty"`
+}
+
+type Request struct {
+	Method      string `json:"method"`
+	URL         URL    `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+type URL struct {
+	Raw      string     `json:"raw"`
+	Host     []string   `json:"host"`
+	Path     []string   `json:"path"`
+	Variable []Variable `json:"variable,omitempty"`
+}
+
+type Variable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Response is an example response attached to an Item's Request.
+type Response struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Code   int    `json:"code"`
+	Body   string `json:"body,omitempty"`
+}
+
+// New returns an empty collection with a {{baseUrl}} collection variable,
+// ready to be passed to AddResourcesFmt.
+func New(name string) *Collection {
+	return &Collection{
+		Info: Info{
+			Name:   name,
+			Schema: "https://schema.getpostman.com/json/collection/v2.1.0/collection.json",
+		},
+		Variable: []Variable{
+			{Key: "baseUrl", Value: "http://localhost:3000"},
+		},
+	}
+}
+
+// folder finds (or creates) the folder Item named name directly under items.
+func folder(items *[]*Item, name string) *Item {
+	for _, it := range *items {
+		if it.Name == name && it.Request == nil {
+			return it
+		}
+	}
+	f := &Item{Name: name}
+	*items = append(*items, f)
+	return f
+}
+
+// Add inserts item into the collection, nesting it under a folder named
+// after route's mount-point prefix (its first path segment), so
+// /articles/... and /admin/... land in separate, nested folders.
+func (c *Collection) Add(route string, item *Item) {
+	segments := strings.Split(strings.Trim(route, "/"), "/")
+	if len(segments) == 0 || segments[0] == "" {
+		c.Item = append(c.Item, item)
+		return
+	}
+
+	f := folder(&c.Item, segments[0])
+	f.Item = append(f.Item, item)
+}
@@ -0,0 +1,110 @@
+package postman_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pressly/chi"
+	"github.com/pressly/chi/docgen/postman"
+	"github.com/pressly/chi/middleware"
+)
+
+func TestWalkerPostman(t *testing.T) {
+	r := Router()
+
+	collection := postman.New("Big Mux")
+
+	if err := collection.AddResourcesFmt(r, postman.DeveloperDocs); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := json.Marshal(collection); err != nil {
+		t.Error(err)
+	}
+
+	var articles, admin *postman.Item
+	for _, it := range collection.Item {
+		switch it.Name {
+		case "articles":
+			articles = it
+		case "admin":
+			admin = it
+		}
+	}
+	if articles == nil {
+		t.Error("expected an \"articles\" folder in the generated collection")
+	}
+	if admin == nil {
+		t.Error("expected an \"admin\" folder in the generated collection")
+	}
+
+	var getArticle *postman.Item
+	for _, it := range articles.Item {
+		if strings.HasPrefix(it.Name, "GET /articles/:articleID") {
+			getArticle = it
+		}
+	}
+	if getArticle == nil {
+		t.Fatal("expected a GET /articles/:articleID item in the \"articles\" folder")
+	}
+
+	url := getArticle.Request.URL
+	if !strings.Contains(url.Raw, ":articleID") {
+		t.Errorf("expected raw URL to use Postman's :articleID path-variable syntax, got %q", url.Raw)
+	}
+	if len(url.Variable) != 1 || url.Variable[0].Key != "articleID" || url.Variable[0].Value == "" {
+		t.Errorf("expected a url.variable entry for articleID with a non-empty default value, got %+v", url.Variable)
+	}
+}
+
+// Copy-pasted from _examples/raml. We can't simply import it, since it's main pkg.
+func Router() chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(middleware.RequestID)
+	r.Use(middleware.Logger)
+	r.Use(middleware.Recoverer)
+
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("root."))
+	})
+
+	r.Route("/articles", func(r chi.Router) {
+		r.Get("/", ListArticles)
+		r.Post("/", CreateArticle)
+
+		r.Route("/:articleID", func(r chi.Router) {
+			r.Get("/", GetArticle) // GET /articles/123
+		})
+	})
+
+	r.Mount("/admin", adminRouter())
+
+	return r
+}
+
+// ListArticles returns an array of Articles.
+func ListArticles(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("[]"))
+}
+
+// CreateArticle persists the posted Article.
+func CreateArticle(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("{}"))
+}
+
+// GetArticle returns the specific Article.
+func GetArticle(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("{}"))
+}
+
+// A completely separate router for administrator routes
+func adminRouter() chi.Router {
+	r := chi.NewRouter()
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("admin: index"))
+	})
+	return r
+}
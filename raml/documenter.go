@@ -0,0 +1,59 @@
+package raml
+
+import "net/http"
+
+// Documenter lets a middleware contribute its own documentation - query
+// parameters, headers, response codes - to every route it wraps.
+// DeveloperDocs calls DocumentRoute for every middleware wrapped via
+// WrapMiddleware while building a route's Resource.
+//
+// This is RAML-specific: DocumentRoute writes directly onto a
+// *raml.Resource, so a Documenter's contributions only ever reach RAML
+// output. openapi.DeveloperDocs and postman.DeveloperDocs have no
+// equivalent hook and silently ignore middlewares wrapped this way.
+type Documenter interface {
+	DocumentRoute(method, route string, res *Resource)
+}
+
+// DocumenterFunc adapts a plain function to a Documenter.
+type DocumenterFunc func(method, route string, res *Resource)
+
+func (f DocumenterFunc) DocumentRoute(method, route string, res *Resource) {
+	f(method, route, res)
+}
+
+// probe is what a WrapMiddleware-wrapped middleware hands back when called
+// with a nil next handler, so documenterFor can recover doc directly from
+// the closure instead of keying off the wrapper's func-value pointer:
+// closures generated from the same literal (as every WrapMiddleware call
+// produces) share that pointer under reflect, so it can't tell one
+// WrapMiddleware call apart from another.
+type probe struct {
+	doc Documenter
+}
+
+func (probe) ServeHTTP(http.ResponseWriter, *http.Request) {}
+
+// WrapMiddleware annotates mw with doc so DeveloperDocs can incorporate
+// whatever mw contributes - query params, headers, response codes - for
+// every route it wraps, without modifying mw's behavior. name is not used
+// in the generated docs: DeveloperDocs' middleware trace labels and links
+// the wrapper returned here, not mw or name.
+func WrapMiddleware(name string, doc Documenter, mw func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if next == nil {
+			return probe{doc: doc}
+		}
+		return mw(next)
+	}
+}
+
+// documenterFor returns the Documenter attached to mw via WrapMiddleware,
+// or nil if mw wasn't wrapped.
+func documenterFor(mw func(http.Handler) http.Handler) Documenter {
+	p, ok := mw(nil).(probe)
+	if !ok {
+		return nil
+	}
+	return p.doc
+}
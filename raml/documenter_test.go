@@ -0,0 +1,44 @@
+package raml_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pressly/chi/docgen/raml"
+)
+
+func TestWrapMiddlewareDocuments(t *testing.T) {
+	paginate := func(next http.Handler) http.Handler { return next }
+	documented := raml.WrapMiddleware("paginate", raml.DocumenterFunc(
+		func(method, route string, res *raml.Resource) {
+			res.QueryParams = map[string]raml.QueryParam{
+				"page":     {Description: "page number"},
+				"per_page": {Description: "items per page"},
+			}
+		},
+	), paginate)
+
+	resource, err := raml.DeveloperDocs("GET", "/articles", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), documented)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := resource.QueryParams["page"]; !ok {
+		t.Error(`expected "page" query parameter to be contributed by the paginate middleware`)
+	}
+	if _, ok := resource.QueryParams["per_page"]; !ok {
+		t.Error(`expected "per_page" query parameter to be contributed by the paginate middleware`)
+	}
+}
+
+func TestUnwrappedMiddlewareIsIgnored(t *testing.T) {
+	plain := func(next http.Handler) http.Handler { return next }
+
+	resource, err := raml.DeveloperDocs("GET", "/articles", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resource.QueryParams) != 0 {
+		t.Error("expected no query parameters from a middleware that was never wrapped with WrapMiddleware")
+	}
+}
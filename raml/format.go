@@ -1,16 +1,29 @@
 package raml
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/pressly/chi"
+	"github.com/pressly/chi/docgen/schema"
 )
 
 type Format struct {
 	Middleware         bool
 	UnexportedHandlers bool
+
+	// SourceLinker builds the URLs DeveloperDocs links handler and
+	// middleware names to. Defaults to GitHubLinker.
+	SourceLinker SourceLinker
+}
+
+func (f Format) linker() SourceLinker {
+	if f.SourceLinker != nil {
+		return f.SourceLinker
+	}
+	return GitHubLinker{}
 }
 
 type FormatFn func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) (*Resource, error)
@@ -28,17 +41,22 @@ func (raml *RAML) AddResourcesFmt(r chi.Routes, fn FormatFn) error {
 	})
 }
 
-// Make this configurable.
-func githubURL(info chi.FuncInfo) string {
-	str := fmt.Sprintf("https://%v#L%v", info.File, info.Line)
-	return strings.Replace(str, "github.com/pressly/api/", "github.com/pressly/api/blob/master/", 1)
+// DeveloperDocs is the default FormatFn, equivalent to Format{}.DeveloperDocs.
+// It links handler and middleware names via a GitHubLinker; use
+// Format.DeveloperDocs to point at a different host.
+func DeveloperDocs(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) (*Resource, error) {
+	return Format{}.DeveloperDocs(method, route, handler, middlewares...)
 }
 
-func DeveloperDocs(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) (*Resource, error) {
+// DeveloperDocs formats a route's documentation the same way the
+// package-level DeveloperDocs does, but links handler and middleware names
+// via f.SourceLinker.
+func (f Format) DeveloperDocs(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) (*Resource, error) {
 	if method == "*" {
 		return nil, nil
 	}
 
+	linker := f.linker()
 	info := chi.GetFuncInfo(handler)
 	pkg := info.Pkg[strings.LastIndex(info.Pkg, "/")+1:]
 
@@ -58,14 +76,14 @@ func DeveloperDocs(method string, route string, handler http.Handler, middleware
 			for i, mw := range middlewares {
 				mwInfo := chi.GetFuncInfo(mw)
 				mwPkg := mwInfo.Pkg[strings.LastIndex(mwInfo.Pkg, "/")+1:]
-				desc += fmt.Sprintf("%v↳ [%v.**%v**](%v)<br />\n", strings.Repeat("&nbsp;", 2*(i+1)), mwPkg, mwInfo.Func, githubURL(mwInfo))
+				desc += fmt.Sprintf("%v↳ [%v.**%v**](%v)<br />\n", strings.Repeat("&nbsp;", 2*(i+1)), mwPkg, mwInfo.Func, linker.Link(mwInfo))
 				if i == len(middlewares)-1 {
 					desc += fmt.Sprintf("%v↳<br />\n", strings.Repeat("&nbsp;", 2*(i+2)))
-					desc += fmt.Sprintf("%v[%v.**%v**](%v)<br />\n", strings.Repeat("&nbsp;", 2*(i+3)), pkg, info.Func, githubURL(info))
+					desc += fmt.Sprintf("%v[%v.**%v**](%v)<br />\n", strings.Repeat("&nbsp;", 2*(i+3)), pkg, info.Func, linker.Link(info))
 					desc += fmt.Sprintf("%v↵<br />\n", strings.Repeat("&nbsp;", 2*(i+2)))
 				}
 				defer func(i int) {
-					desc += fmt.Sprintf("%v↵ [%v.**%v**](%v)<br />\n", strings.Repeat("&nbsp;", 2*(i+1)), mwPkg, mwInfo.Func, githubURL(mwInfo))
+					desc += fmt.Sprintf("%v↵ [%v.**%v**](%v)<br />\n", strings.Repeat("&nbsp;", 2*(i+1)), mwPkg, mwInfo.Func, linker.Link(mwInfo))
 				}(i)
 			}
 		}()
@@ -87,5 +105,56 @@ func DeveloperDocs(method string, route string, handler http.Handler, middleware
 		resource.Responses[204] = Response{}
 	}
 
+	for _, mw := range middlewares {
+		if doc := documenterFor(mw); doc != nil {
+			doc.DocumentRoute(method, route, resource)
+		}
+	}
+
 	return resource, nil
 }
+
+// DeveloperDocsWithResolver returns a FormatFn that behaves like
+// DeveloperDocs, but additionally inspects the handler's source for the
+// struct passed to render.Bind and render.JSON and fills in the resulting
+// JSON Schema under Resource.Body and each Response's Body. resolver
+// supplies schemas for types the AST walker can't resolve on its own
+// (interfaces, generated proto types, etc.) and may be nil.
+func DeveloperDocsWithResolver(resolver schema.Resolver) FormatFn {
+	return func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) (*Resource, error) {
+		resource, err := DeveloperDocs(method, route, handler, middlewares...)
+		if err != nil || resource == nil {
+			return resource, err
+		}
+
+		info := chi.GetFuncInfo(handler)
+		body, response, err := schema.Infer(info.File, info.Func, resolver)
+		if err != nil {
+			// Best effort: a handler whose body we can't parse still gets
+			// the rest of its documentation.
+			return resource, nil
+		}
+
+		if body != nil {
+			resource.Body = bodyOf(body)
+		}
+		if response != nil {
+			for code, resp := range resource.Responses {
+				resp.Body = bodyOf(response)
+				resource.Responses[code] = resp
+			}
+		}
+
+		return resource, nil
+	}
+}
+
+func bodyOf(s *schema.Schema) map[string]Body {
+	raw, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil
+	}
+	return map[string]Body{
+		"application/json": {Schema: string(raw)},
+	}
+}
@@ -0,0 +1,205 @@
+package raml
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// RAML is the root of a RAML 0.8 document, built up one route at a time
+// via Add (or AddResourcesFmt).
+type RAML struct {
+	Title     string
+	BaseUri   string
+	Version   string
+	MediaType string
+
+	Resources map[string]*Resource
+}
+
+// Responses maps an HTTP status code to its Response.
+type Responses map[int]Response
+
+// Response describes a single status code's response.
+type Response struct {
+	Description string
+	Body        map[string]Body
+	Headers     map[string]Header
+}
+
+// Header describes a single request or response header.
+type Header struct {
+	Description string
+	Required    bool
+}
+
+// QueryParam describes a single query string parameter.
+type QueryParam struct {
+	Description string
+	Required    bool
+}
+
+// Body describes a single media type's payload, e.g. the "application/json"
+// entry under a method or response.
+type Body struct {
+	Schema  string
+	Example string
+}
+
+// Resource is a RAML resource. The same type is reused both for path nodes
+// (populated via Resources, holding further nested Resources) and for the
+// per-method documentation added through RAML.Add (populated via
+// Description/Responses/Body and hung off a path node's Methods map).
+type Resource struct {
+	Description string
+	Responses   Responses
+	Body        map[string]Body
+	QueryParams map[string]QueryParam
+	Headers     map[string]Header
+
+	Resources map[string]*Resource
+	Methods   map[string]*Resource
+}
+
+// Add attaches resource as route's documentation for method, creating any
+// intermediate path nodes that don't exist yet.
+func (raml *RAML) Add(method string, route string, resource *Resource) error {
+	if raml.Resources == nil {
+		raml.Resources = map[string]*Resource{}
+	}
+
+	node := raml.resourceFor(route)
+	if node.Methods == nil {
+		node.Methods = map[string]*Resource{}
+	}
+	node.Methods[strings.ToLower(method)] = resource
+
+	return nil
+}
+
+// resourceFor returns the path node for route, creating intermediate nodes
+// as needed.
+func (raml *RAML) resourceFor(route string) *Resource {
+	segments := strings.Split(strings.Trim(route, "/"), "/")
+
+	resources := raml.Resources
+	node := &Resource{}
+	found := false
+	for _, seg := range segments {
+		if seg == "" {
+			continue
+		}
+		key := "/" + seg
+
+		next, ok := resources[key]
+		if !ok {
+			next = &Resource{Resources: map[string]*Resource{}}
+			resources[key] = next
+		}
+		if next.Resources == nil {
+			next.Resources = map[string]*Resource{}
+		}
+
+		node = next
+		resources = next.Resources
+		found = true
+	}
+
+	if !found {
+		// route was "/"
+		node, found = raml.Resources["/"]
+		if !found {
+			node = &Resource{Resources: map[string]*Resource{}}
+			raml.Resources["/"] = node
+		}
+	}
+
+	return node
+}
+
+var methodOrder = []string{"get", "post", "put", "patch", "delete", "head", "options"}
+
+// MarshalYAML renders the document in RAML's convention of merging
+// resource paths directly into the top-level map, e.g. "/articles:" rather
+// than nesting them under a "resources:" key.
+func (raml *RAML) MarshalYAML() (interface{}, error) {
+	out := yaml.MapSlice{
+		{Key: "title", Value: raml.Title},
+	}
+	if raml.BaseUri != "" {
+		out = append(out, yaml.MapItem{Key: "baseUri", Value: raml.BaseUri})
+	}
+	if raml.Version != "" {
+		out = append(out, yaml.MapItem{Key: "version", Value: raml.Version})
+	}
+	if raml.MediaType != "" {
+		out = append(out, yaml.MapItem{Key: "mediaType", Value: raml.MediaType})
+	}
+
+	paths := make([]string, 0, len(raml.Resources))
+	for path := range raml.Resources {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		out = append(out, yaml.MapItem{Key: path, Value: raml.Resources[path]})
+	}
+
+	return out, nil
+}
+
+// MarshalYAML renders a path node as its nested resources and methods.
+func (resource *Resource) MarshalYAML() (interface{}, error) {
+	out := yaml.MapSlice{}
+
+	if resource.Description != "" {
+		out = append(out, yaml.MapItem{Key: "description", Value: resource.Description})
+	}
+	if len(resource.Responses) > 0 {
+		out = append(out, yaml.MapItem{Key: "responses", Value: resource.Responses})
+	}
+	if len(resource.Body) > 0 {
+		out = append(out, yaml.MapItem{Key: "body", Value: resource.Body})
+	}
+	if len(resource.QueryParams) > 0 {
+		out = append(out, yaml.MapItem{Key: "queryParameters", Value: resource.QueryParams})
+	}
+	if len(resource.Headers) > 0 {
+		out = append(out, yaml.MapItem{Key: "headers", Value: resource.Headers})
+	}
+
+	for _, method := range methodOrder {
+		if m, ok := resource.Methods[method]; ok {
+			out = append(out, yaml.MapItem{Key: method, Value: m})
+		}
+	}
+
+	paths := make([]string, 0, len(resource.Resources))
+	for path := range resource.Resources {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		out = append(out, yaml.MapItem{Key: path, Value: resource.Resources[path]})
+	}
+
+	return out, nil
+}
+
+// MarshalYAML renders responses keyed by their numeric status code, e.g.
+// "200:", matching RAML's convention.
+func (responses Responses) MarshalYAML() (interface{}, error) {
+	codes := make([]int, 0, len(responses))
+	for code := range responses {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+
+	out := yaml.MapSlice{}
+	for _, code := range codes {
+		out = append(out, yaml.MapItem{Key: fmt.Sprintf("%d", code), Value: responses[code]})
+	}
+	return out, nil
+}
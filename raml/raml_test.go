@@ -25,28 +25,49 @@ func TestWalkerRAML(t *testing.T) {
 		MediaType: "application/json",
 	}
 
-	if err := chi.Walk(r, func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
-		handlerInfo := chi.GetFuncInfo(handler)
-		resource := &raml.Resource{
-			Description: handlerInfo.Comment,
-		}
-
-		return ramlDocs.Add(method, route, resource)
-	}); err != nil {
+	if err := ramlDocs.AddResourcesFmt(r, raml.DeveloperDocs); err != nil {
 		t.Error(err)
 	}
 
-	_, err := yaml.Marshal(ramlDocs)
-	if err != nil {
+	if _, err := yaml.Marshal(ramlDocs); err != nil {
 		t.Error(err)
 	}
+
+	articles := ramlDocs.Resources["/articles"]
+	if articles == nil || articles.Methods["get"] == nil {
+		t.Fatal("expected a GET method on /articles")
+	}
+	if _, ok := articles.Methods["get"].QueryParams["page"]; !ok {
+		t.Error(`expected the paginate middleware (via WrapMiddleware) to contribute a "page" query parameter to GET /articles`)
+	}
+	if _, ok := articles.Methods["get"].QueryParams["per_page"]; !ok {
+		t.Error(`expected the paginate middleware (via WrapMiddleware) to contribute a "per_page" query parameter to GET /articles`)
+	}
+
+	admin := ramlDocs.Resources["/admin"]
+	if admin == nil || admin.Methods["get"] == nil {
+		t.Fatal("expected a GET method on /admin")
+	}
+	adminGet := admin.Methods["get"]
+	if _, ok := adminGet.Responses[401]; !ok {
+		t.Error("expected the AdminOnly middleware to contribute a 401 response")
+	}
+	if _, ok := adminGet.Responses[403]; !ok {
+		t.Error("expected the AdminOnly middleware to contribute a 403 response")
+	}
+	if _, ok := adminGet.Headers["Authorization"]; !ok {
+		t.Error("expected the AdminOnly middleware to contribute an Authorization header")
+	}
+	if _, ok := adminGet.Headers["X-Request-ID"]; !ok {
+		t.Error("expected the RequestID middleware to contribute an X-Request-ID header")
+	}
 }
 
 // Copy-pasted from _examples/raml. We can't simply import it, since it's main pkg.
 func Router() chi.Router {
 	r := chi.NewRouter()
 
-	r.Use(middleware.RequestID)
+	r.Use(raml.WrapMiddleware("RequestID", requestIDDoc, middleware.RequestID))
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 
@@ -64,7 +85,7 @@ func Router() chi.Router {
 
 	// RESTy routes for "articles" resource
 	r.Route("/articles", func(r chi.Router) {
-		r.With(paginate).Get("/", ListArticles)
+		r.With(raml.WrapMiddleware("paginate", paginateDoc, paginate)).Get("/", ListArticles)
 		r.Post("/", CreateArticle)       // POST /articles
 		r.Get("/search", SearchArticles) // GET /articles/search
 
@@ -199,7 +220,7 @@ func DeleteArticle(w http.ResponseWriter, r *http.Request) {
 // A completely separate router for administrator routes
 func adminRouter() chi.Router {
 	r := chi.NewRouter()
-	r.Use(AdminOnly)
+	r.Use(raml.WrapMiddleware("AdminOnly", adminOnlyDoc, AdminOnly))
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("admin: index"))
 	})
@@ -224,6 +245,25 @@ func AdminOnly(next http.Handler) http.Handler {
 	})
 }
 
+// adminOnlyDoc documents what AdminOnly adds to every route it wraps: the
+// Authorization header it reads, and the 401/403 it can respond with
+// instead of reaching the handler.
+var adminOnlyDoc = raml.DocumenterFunc(func(method, route string, res *raml.Resource) {
+	if res.Headers == nil {
+		res.Headers = map[string]raml.Header{}
+	}
+	res.Headers["Authorization"] = raml.Header{
+		Description: "bearer token for an administrator account",
+		Required:    true,
+	}
+
+	if res.Responses == nil {
+		res.Responses = raml.Responses{}
+	}
+	res.Responses[401] = raml.Response{Description: "missing or invalid credentials"}
+	res.Responses[403] = raml.Response{Description: "authenticated, but not an administrator"}
+})
+
 // paginate is a stub, but very possible to implement middleware logic
 // to handle the request params for handling a paginated request.
 func paginate(next http.Handler) http.Handler {
@@ -234,6 +274,25 @@ func paginate(next http.Handler) http.Handler {
 	})
 }
 
+// paginateDoc documents the query parameters paginate reads.
+var paginateDoc = raml.DocumenterFunc(func(method, route string, res *raml.Resource) {
+	if res.QueryParams == nil {
+		res.QueryParams = map[string]raml.QueryParam{}
+	}
+	res.QueryParams["page"] = raml.QueryParam{Description: "page number to return"}
+	res.QueryParams["per_page"] = raml.QueryParam{Description: "number of results per page"}
+})
+
+// requestIDDoc documents the response header middleware.RequestID adds.
+var requestIDDoc = raml.DocumenterFunc(func(method, route string, res *raml.Resource) {
+	if res.Headers == nil {
+		res.Headers = map[string]raml.Header{}
+	}
+	res.Headers["X-Request-ID"] = raml.Header{
+		Description: "unique identifier echoed back for request correlation",
+	}
+})
+
 //--
 
 // Below are a bunch of helper functions that mock some kind of storage
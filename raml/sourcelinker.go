@@ -0,0 +1,117 @@
+package raml
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pressly/chi"
+)
+
+// SourceLinker builds a browsable URL pointing at the source location
+// described by info, used to link middleware and handler names in
+// generated docs back to their definition. info.File is chi's own
+// "host/org/repo/path/to/file.go" form (the package's import path, not a
+// local filesystem path).
+//
+// chi.FuncInfo only carries a single Line, with no end line for the
+// declaration it points at, so every built-in SourceLinker below emits a
+// single-line anchor (e.g. "#L12"); none of them can produce a range
+// anchor (e.g. "#L12-L14") even on hosts whose URL convention supports
+// one.
+type SourceLinker interface {
+	Link(info chi.FuncInfo) string
+}
+
+// splitRepo pulls the "host/org/repo" prefix out of a chi.FuncInfo.File
+// value and returns it alongside the file's path within that repo, given
+// how many path segments after the host make up org+repo (2 for
+// "org/repo").
+func splitRepo(file string, segments int) (repo string, rel string) {
+	parts := strings.Split(file, "/")
+	n := segments + 1 // + the host itself
+	if len(parts) <= n {
+		return file, ""
+	}
+	return strings.Join(parts[:n], "/"), strings.Join(parts[n:], "/")
+}
+
+func branchOrDefault(branch string) string {
+	if branch == "" {
+		return "master"
+	}
+	return branch
+}
+
+// GitHubLinker links to github.com (or a GitHub Enterprise host) using its
+// "/blob/<branch>/<path>#L<line>" URL convention. Always single-line - see
+// SourceLinker.
+type GitHubLinker struct {
+	Branch string // defaults to "master"
+}
+
+func (l GitHubLinker) Link(info chi.FuncInfo) string {
+	repo, rel := splitRepo(info.File, 2)
+	return fmt.Sprintf("https://%s/blob/%s/%s#L%d", repo, branchOrDefault(l.Branch), rel, info.Line)
+}
+
+// GitLabLinker links to gitlab.com or a self-hosted GitLab instance using
+// its "/-/blob/<branch>/<path>#L<line>" URL convention. Always
+// single-line - see SourceLinker.
+type GitLabLinker struct {
+	Branch string // defaults to "master"
+}
+
+func (l GitLabLinker) Link(info chi.FuncInfo) string {
+	repo, rel := splitRepo(info.File, 2)
+	return fmt.Sprintf("https://%s/-/blob/%s/%s#L%d", repo, branchOrDefault(l.Branch), rel, info.Line)
+}
+
+// BitbucketLinker links to bitbucket.org (or Bitbucket Server) using its
+// "/src/<branch>/<path>#lines-<line>" URL convention. Bitbucket's own
+// anchor syntax supports a range ("#lines-12:14"), but this always emits
+// single-line - see SourceLinker.
+type BitbucketLinker struct {
+	Branch string // defaults to "master"
+}
+
+func (l BitbucketLinker) Link(info chi.FuncInfo) string {
+	repo, rel := splitRepo(info.File, 2)
+	return fmt.Sprintf("https://%s/src/%s/%s#lines-%d", repo, branchOrDefault(l.Branch), rel, info.Line)
+}
+
+// GiteaLinker links to a Gitea or Forgejo instance - including self-hosted
+// hosts that don't advertise the software in their hostname - using its
+// "/src/branch/<branch>/<path>#L<line>" convention. Always single-line -
+// see SourceLinker.
+type GiteaLinker struct {
+	Branch string // defaults to "master"
+}
+
+func (l GiteaLinker) Link(info chi.FuncInfo) string {
+	repo, rel := splitRepo(info.File, 2)
+	return fmt.Sprintf("https://%s/src/branch/%s/%s#L%d", repo, branchOrDefault(l.Branch), rel, info.Line)
+}
+
+// ModulePrefixRewriter maps a Go module prefix, as it appears in
+// chi.FuncInfo.File (e.g. "github.com/acme/private-repo"), onto an
+// already-complete blob URL prefix (e.g.
+// "https://git.internal.acme/acme/private-repo/blob/main"), so an internal
+// fork or mirror can be linked without writing a full SourceLinker.
+// Fallback, if set, handles files outside Prefix. Always single-line -
+// see SourceLinker.
+type ModulePrefixRewriter struct {
+	Prefix   string
+	Rewrite  string
+	Fallback SourceLinker
+}
+
+func (m ModulePrefixRewriter) Link(info chi.FuncInfo) string {
+	if !strings.HasPrefix(info.File, m.Prefix) {
+		if m.Fallback != nil {
+			return m.Fallback.Link(info)
+		}
+		return ""
+	}
+	rel := strings.TrimPrefix(info.File, m.Prefix)
+	return fmt.Sprintf("%s%s#L%d", m.Rewrite, rel, info.Line)
+}
@@ -0,0 +1,77 @@
+package raml_test
+
+import (
+	"testing"
+
+	"github.com/pressly/chi"
+	"github.com/pressly/chi/docgen/raml"
+)
+
+func TestSourceLinkers(t *testing.T) {
+	info := chi.FuncInfo{
+		File: "github.com/pressly/api/rest/articles.go",
+		Line: 42,
+	}
+
+	cases := []struct {
+		name   string
+		linker raml.SourceLinker
+		want   string
+	}{
+		{
+			"GitHub",
+			raml.GitHubLinker{},
+			"https://github.com/pressly/api/blob/master/rest/articles.go#L42",
+		},
+		{
+			"GitLab",
+			raml.GitLabLinker{Branch: "main"},
+			"https://github.com/pressly/api/-/blob/main/rest/articles.go#L42",
+		},
+		{
+			"Bitbucket",
+			raml.BitbucketLinker{},
+			"https://github.com/pressly/api/src/master/rest/articles.go#lines-42",
+		},
+		{
+			"Gitea",
+			raml.GiteaLinker{},
+			"https://github.com/pressly/api/src/branch/master/rest/articles.go#L42",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.linker.Link(info); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModulePrefixRewriter(t *testing.T) {
+	info := chi.FuncInfo{
+		File: "github.com/acme/private-repo/handlers/articles.go",
+		Line: 7,
+	}
+
+	m := raml.ModulePrefixRewriter{
+		Prefix:  "github.com/acme/private-repo",
+		Rewrite: "https://git.internal.acme/acme/private-repo/blob/main",
+	}
+
+	want := "https://git.internal.acme/acme/private-repo/blob/main/handlers/articles.go#L7"
+	if got := m.Link(info); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	other := raml.ModulePrefixRewriter{
+		Prefix:   "github.com/acme/private-repo",
+		Rewrite:  "https://git.internal.acme/acme/private-repo/blob/main",
+		Fallback: raml.GitHubLinker{},
+	}
+	want = "https://github.com/pressly/api/blob/master/rest/articles.go#L42"
+	if got := other.Link(chi.FuncInfo{File: "github.com/pressly/api/rest/articles.go", Line: 42}); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
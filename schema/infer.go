@@ -0,0 +1,403 @@
+package schema
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Infer parses the source file handlerFile and looks for the handler
+// function named handlerFunc, returning the schema inferred for the value
+// passed to render.Bind(r.Body, &x) (the request body) and the one passed
+// to render.JSON(w, r, x) (the response body). Either return value may be
+// nil if no matching call was found. resolver may be nil.
+func Infer(handlerFile string, handlerFunc string, resolver Resolver) (body *Schema, response *Schema, err error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, handlerFile, nil, parser.ParseComments)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decl := findFuncDecl(file, handlerFunc)
+	if decl == nil || decl.Body == nil {
+		return nil, nil, nil
+	}
+
+	r := &resolution{file: file, resolver: resolver}
+
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		pkgIdent, ok := sel.X.(*ast.Ident)
+		if !ok || pkgIdent.Name != "render" {
+			return true
+		}
+
+		switch sel.Sel.Name {
+		case "Bind":
+			if len(call.Args) == 2 {
+				body = r.schemaForExpr(decl, call.Args[1])
+			}
+		case "JSON":
+			if len(call.Args) == 3 {
+				response = r.schemaForExpr(decl, call.Args[2])
+			}
+		}
+		return true
+	})
+
+	return body, response, nil
+}
+
+func findFuncDecl(file *ast.File, name string) *ast.FuncDecl {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if ok && fn.Recv == nil && fn.Name.Name == name {
+			return fn
+		}
+	}
+	return nil
+}
+
+type resolution struct {
+	file     *ast.File
+	resolver Resolver
+}
+
+// schemaForExpr resolves the schema for expr, which is either the 2nd
+// argument to render.Bind (typically &data) or the 3rd to render.JSON
+// (typically a variable or a dereferenced pointer).
+func (r *resolution) schemaForExpr(decl *ast.FuncDecl, expr ast.Expr) *Schema {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	typeExpr := findLocalType(decl, ident.Name)
+	if typeExpr == nil {
+		return nil
+	}
+
+	return r.schemaForType(typeExpr, map[string]bool{})
+}
+
+// findLocalType scans decl's body for the assignment (var decl, :=, or a
+// plain = reassignment) that gives name its value, and returns the AST type
+// expression that value evaluates to.
+func findLocalType(decl *ast.FuncDecl, name string) ast.Expr {
+	var found ast.Expr
+
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		if found != nil {
+			return false
+		}
+
+		switch stmt := n.(type) {
+		case *ast.DeclStmt:
+			gen, ok := stmt.Decl.(*ast.GenDecl)
+			if !ok {
+				return true
+			}
+			for _, spec := range gen.Specs {
+				vs, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				for _, id := range vs.Names {
+					if id.Name == name && vs.Type != nil {
+						found = vs.Type
+					}
+				}
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range stmt.Lhs {
+				id, ok := lhs.(*ast.Ident)
+				if !ok || id.Name != name || i >= len(stmt.Rhs) {
+					continue
+				}
+				if t := typeOfValue(decl, stmt.Rhs[i]); t != nil {
+					found = t
+				}
+			}
+		}
+		return true
+	})
+
+	return found
+}
+
+// typeOfValue resolves the static type of a value expression one level at a
+// time: a composite literal, a type assertion (e.g.
+// `r.Context().Value("article").(*Article)`), an address-of another
+// resolvable value, or a selector into an already-resolvable local (e.g.
+// `data.Article`, following the embedded-field composition/masking trick
+// back to *Article). Returns nil if expr isn't one of these shapes.
+func typeOfValue(decl *ast.FuncDecl, expr ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.CompositeLit:
+		return e.Type
+	case *ast.TypeAssertExpr:
+		return e.Type
+	case *ast.UnaryExpr:
+		if e.Op == token.AND {
+			if t := typeOfValue(decl, e.X); t != nil {
+				return &ast.StarExpr{X: t}
+			}
+		}
+	case *ast.Ident:
+		return findLocalType(decl, e.Name)
+	case *ast.SelectorExpr:
+		base := typeOfValue(decl, e.X)
+		return fieldType(base, e.Sel.Name)
+	}
+	return nil
+}
+
+// fieldType looks up name among t's fields, matching either a named field or
+// an embedded field by its type's identifier, so that e.g. `data.Article`
+// resolves against an anonymous `*Article` embed.
+func fieldType(t ast.Expr, name string) ast.Expr {
+	st, ok := t.(*ast.StructType)
+	if !ok {
+		return nil
+	}
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 {
+			if embeddedName(field.Type) == name {
+				return field.Type
+			}
+			continue
+		}
+		for _, id := range field.Names {
+			if id.Name == name {
+				return field.Type
+			}
+		}
+	}
+	return nil
+}
+
+// embeddedName returns the field name an embedded field is addressed by:
+// the identifier of its (possibly pointer or qualified) type.
+func embeddedName(t ast.Expr) string {
+	switch e := t.(type) {
+	case *ast.StarExpr:
+		return embeddedName(e.X)
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	}
+	return ""
+}
+
+// schemaForType builds a Schema for a Go type expression, recursing into
+// struct fields (honoring json tags and ,omitempty) and named types
+// declared elsewhere in the same file. visiting guards against infinite
+// recursion on self-referential types; each *ast.Ident branch clears its
+// own entry once it returns, so the guard only suppresses a type that
+// recurs on its own expansion path, not an unrelated sibling field that
+// happens to share the same named type.
+func (r *resolution) schemaForType(expr ast.Expr, visiting map[string]bool) *Schema {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return r.schemaForType(t.X, visiting)
+
+	case *ast.StructType:
+		return r.schemaForStruct(t, visiting)
+
+	case *ast.ArrayType:
+		return &Schema{Type: "array", Items: r.schemaForType(t.Elt, visiting)}
+
+	case *ast.MapType:
+		return &Schema{Type: "object"}
+
+	case *ast.InterfaceType:
+		return &Schema{Type: "object"}
+
+	case *ast.Ident:
+		if s := builtinSchema(t.Name); s != nil {
+			return s
+		}
+		if visiting[t.Name] {
+			return &Schema{Type: "object"}
+		}
+		if decl := findTypeDecl(r.file, t.Name); decl != nil {
+			visiting[t.Name] = true
+			s := r.schemaForType(decl, visiting)
+			delete(visiting, t.Name)
+			return s
+		}
+		if r.resolver != nil {
+			if s, ok := r.resolver.Resolve(r.file.Name.Name, t.Name); ok {
+				return s
+			}
+		}
+		return &Schema{Type: "object"}
+
+	case *ast.SelectorExpr:
+		pkg := ""
+		if id, ok := t.X.(*ast.Ident); ok {
+			pkg = importPath(r.file, id.Name)
+		}
+		if r.resolver != nil {
+			if s, ok := r.resolver.Resolve(pkg, t.Sel.Name); ok {
+				return s
+			}
+		}
+		return &Schema{Type: "object"}
+	}
+
+	return &Schema{Type: "object"}
+}
+
+// importPath resolves ident - the package-qualifying identifier used at a
+// call site, e.g. "pb" in pb.Article - to the import path file actually
+// imports it under: the ImportSpec's own path if ident is its alias, or
+// the unaliased import whose final path segment matches ident otherwise.
+// This is what lets a Resolver see the same import path
+// chi.GetFuncInfo's Pkg field would report instead of just the local
+// alias. Falls back to ident itself if nothing matches (e.g. an unaliased
+// import whose package name differs from its path's final segment).
+func importPath(file *ast.File, ident string) string {
+	for _, imp := range file.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		if imp.Name != nil {
+			if imp.Name.Name == ident {
+				return path
+			}
+			continue
+		}
+		if path[strings.LastIndex(path, "/")+1:] == ident {
+			return path
+		}
+	}
+	return ident
+}
+
+func (r *resolution) schemaForStruct(st *ast.StructType, visiting map[string]bool) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	required := map[string]bool{}
+
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 {
+			// Anonymous embedding, e.g. `*Article` in CreateArticle's
+			// request struct: merge the embedded type's properties in so
+			// a later field (like the `OmitID` masking trick) can override
+			// individual keys such as "id", required-ness included.
+			embedded := r.schemaForType(field.Type, visiting)
+			embeddedRequired := map[string]bool{}
+			for _, k := range embedded.Required {
+				embeddedRequired[k] = true
+			}
+			for k, v := range embedded.Properties {
+				s.Properties[k] = v
+				required[k] = embeddedRequired[k]
+			}
+			continue
+		}
+
+		for _, name := range field.Names {
+			key, omitempty, skip := jsonKey(field.Tag, name.Name)
+			if skip {
+				continue
+			}
+			s.Properties[key] = r.schemaForType(field.Type, visiting)
+			required[key] = !omitempty
+		}
+	}
+
+	for key, req := range required {
+		if req {
+			s.Required = append(s.Required, key)
+		}
+	}
+	sort.Strings(s.Required)
+
+	return s
+}
+
+// jsonKey reads a struct field's `json:"..."` tag, returning the effective
+// property key, whether it's ,omitempty, and whether it should be skipped
+// entirely (json:"-").
+func jsonKey(tag *ast.BasicLit, fallback string) (key string, omitempty bool, skip bool) {
+	if tag == nil {
+		return fallback, false, false
+	}
+
+	value, err := strconv.Unquote(tag.Value)
+	if err != nil {
+		return fallback, false, false
+	}
+
+	jsonTag := ""
+	for _, part := range strings.Split(value, " ") {
+		if strings.HasPrefix(part, `json:"`) {
+			jsonTag = strings.TrimSuffix(strings.TrimPrefix(part, `json:"`), `"`)
+		}
+	}
+	if jsonTag == "" {
+		return fallback, false, false
+	}
+
+	opts := strings.Split(jsonTag, ",")
+	key = opts[0]
+	if key == "-" {
+		return "", false, true
+	}
+	if key == "" {
+		key = fallback
+	}
+	for _, opt := range opts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return key, omitempty, false
+}
+
+func findTypeDecl(file *ast.File, name string) ast.Expr {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if ok && ts.Name.Name == name {
+				return ts.Type
+			}
+		}
+	}
+	return nil
+}
+
+func builtinSchema(name string) *Schema {
+	switch name {
+	case "string":
+		return &Schema{Type: "string"}
+	case "bool":
+		return &Schema{Type: "boolean"}
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64":
+		return &Schema{Type: "number"}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/pressly/chi/docgen/schema"
+)
+
+func TestInfer(t *testing.T) {
+	body, response, err := schema.Infer("testdata/handlers.go", "CreateArticle", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if body == nil {
+		t.Fatal("expected a request body schema from render.Bind")
+	}
+	if _, ok := body.Properties["id"]; !ok {
+		t.Error(`expected the embedded *Article's "id" property to surface through composition`)
+	}
+	if contains(body.Required, "id") {
+		t.Error(`expected "id" to be masked out of Required by the OmitID ",omitempty" trick`)
+	}
+
+	if response == nil {
+		t.Fatal("expected a response body schema from render.JSON")
+	}
+	if response.Properties["title"].Type != "string" {
+		t.Error(`expected response "title" property to be a string`)
+	}
+	if contains(response.Required, "title") {
+		t.Error(`expected "title" to be optional due to its ",omitempty" tag`)
+	}
+	if !contains(response.Required, "id") {
+		t.Error(`expected "id" to be required`)
+	}
+}
+
+func TestInferResponseViaTypeAssertion(t *testing.T) {
+	_, response, err := schema.Infer("testdata/handlers.go", "GetArticle", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response == nil {
+		t.Fatal("expected a response body schema from render.JSON")
+	}
+	if _, ok := response.Properties["id"]; !ok {
+		t.Error(`expected "id" to be resolved from the r.Context().Value(...).(*Article) type assertion`)
+	}
+}
+
+func TestInferSiblingFieldsOfSameType(t *testing.T) {
+	_, response, err := schema.Infer("testdata/handlers.go", "GetArticleWithUsers", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response == nil {
+		t.Fatal("expected a response body schema from render.JSON")
+	}
+
+	author, ok := response.Properties["author"]
+	if !ok {
+		t.Fatal(`expected an "author" property`)
+	}
+	editor, ok := response.Properties["editor"]
+	if !ok {
+		t.Fatal(`expected an "editor" property`)
+	}
+
+	if _, ok := author.Properties["name"]; !ok {
+		t.Error(`expected "author" to expand User's "name" property`)
+	}
+	if _, ok := editor.Properties["name"]; !ok {
+		t.Error(`expected "editor" to expand User's "name" property too, not flatten to an empty object since it's the second occurrence of User`)
+	}
+}
+
+func TestInferResolvesQualifiedTypeViaImportPath(t *testing.T) {
+	var gotPkg, gotType string
+	resolver := schema.ResolverFunc(func(pkg, typeName string) (*schema.Schema, bool) {
+		gotPkg, gotType = pkg, typeName
+		return &schema.Schema{Type: "object"}, true
+	})
+
+	_, response, err := schema.Infer("testdata/handlers.go", "GetArticleProto", resolver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response == nil {
+		t.Fatal("expected a response body schema from render.JSON")
+	}
+	if gotPkg != "example.com/proto/articlepb" {
+		t.Errorf("expected Resolve to see the aliased import's real path, got pkg=%q", gotPkg)
+	}
+	if gotType != "Article" {
+		t.Errorf("expected Resolve to see typeName=\"Article\", got %q", gotType)
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+// Package schema infers JSON Schema for the request and response bodies of
+// a chi handler by reading its source: it looks for the struct passed to
+// render.Bind and render.JSON and walks its fields.
+package schema
+
+// Schema is a minimal JSON Schema (draft-07 subset), sufficient to
+// describe the bodies docgen infers from a handler's signature.
+type Schema struct {
+	Type       string             `json:"type,omitempty" yaml:"type,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Items      *Schema            `json:"items,omitempty" yaml:"items,omitempty"`
+	Required   []string           `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+// Resolver lets callers supply or override the schema for a type the AST
+// walker can't resolve on its own, e.g. an interface, a type from another
+// package, or a generated proto message. For a type reached through a
+// qualified selector (e.g. pb.Article), pkg is resolved from the
+// handler's import spec to that import's real path - not the bare local
+// alias used at the call site - so it matches chi.GetFuncInfo's Pkg
+// field; typeName is the identifier selected off it (e.g. "Article").
+// For a type named directly (an *ast.Ident with no local declaration,
+// e.g. via a dot import), pkg falls back to the handler's own package
+// name instead.
+type Resolver interface {
+	Resolve(pkg, typeName string) (*Schema, bool)
+}
+
+// ResolverFunc adapts a plain function to a Resolver.
+type ResolverFunc func(pkg, typeName string) (*Schema, bool)
+
+// Resolve calls f.
+func (f ResolverFunc) Resolve(pkg, typeName string) (*Schema, bool) {
+	return f(pkg, typeName)
+}
@@ -0,0 +1,65 @@
+// Package testdata is a fixture for schema_test.go: a standalone file that
+// looks like a real chi handler, without depending on chi or render so the
+// AST walker can be exercised in isolation. Go's tooling ignores testdata
+// directories, so this file is never itself compiled.
+package testdata
+
+import (
+	pb "example.com/proto/articlepb"
+)
+
+type Article struct {
+	ID    string `json:"id"`
+	Title string `json:"title,omitempty"`
+}
+
+// CreateArticle mirrors raml/raml_test.go's CreateArticle: embedding
+// *Article and masking "id" with an omitempty interface field so it can't
+// be set by the client, then reassigning through the embedded field's
+// selector rather than a fresh composite literal.
+func CreateArticle(w ResponseWriter, r *Request) {
+	var data struct {
+		*Article
+		OmitID interface{} `json:"id,omitempty"`
+	}
+	render.Bind(r.Body, &data)
+
+	article := data.Article
+	render.JSON(w, r, article)
+}
+
+// GetArticle mirrors raml/raml_test.go's GetArticle: pulling the response
+// value off the request context through a type assertion rather than a
+// composite literal.
+func GetArticle(w ResponseWriter, r *Request) {
+	article := r.Context().Value("article").(*Article)
+	render.JSON(w, r, article)
+}
+
+// GetArticleProto exercises schemaForType's qualified-selector case: a
+// response type reached through an aliased import (pb "example.com/...")
+// rather than a local type declaration, the way a generated proto message
+// would be.
+func GetArticleProto(w ResponseWriter, r *Request) {
+	article := r.Context().Value("article").(*pb.Article)
+	render.JSON(w, r, article)
+}
+
+type User struct {
+	Name string `json:"name"`
+}
+
+// GetArticleWithUsers exercises the visiting guard in schemaForType: Author
+// and Editor are sibling fields of the same named type, so the second
+// occurrence must be expanded just like the first, not suppressed as if it
+// were a self-reference.
+func GetArticleWithUsers(w ResponseWriter, r *Request) {
+	article := struct {
+		Author *User `json:"author"`
+		Editor *User `json:"editor"`
+	}{}
+	render.JSON(w, r, article)
+}
+
+type ResponseWriter interface{}
+type Request struct{ Body interface{} }
@@ -0,0 +1,6 @@
+package docgen
+
+import "embed"
+
+//go:embed ui
+var uiFS embed.FS